@@ -0,0 +1,123 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// journalEntry is a modification entry in the state change journal that can be reverted
+// on demand.
+type journalEntry interface {
+	// revert undoes the state change that this journal entry represents.
+	revert(*Statedb)
+}
+
+type (
+	// createObjectChange is appended when a state object did not previously exist.
+	createObjectChange struct {
+		account *common.Address
+	}
+
+	// resetObjectChange is appended when a state object is replaced, e.g. by CreateAccount
+	// on top of a suicided account within the same block.
+	resetObjectChange struct {
+		prev *stateObject
+	}
+
+	suicideChange struct {
+		account    *common.Address
+		prev       bool // whether the account was previously suicided
+		prevAmount *big.Int
+	}
+
+	// Changes to individual accounts.
+	balanceChange struct {
+		account *common.Address
+		prev    *big.Int
+	}
+	nonceChange struct {
+		account *common.Address
+		prev    uint64
+	}
+	codeChange struct {
+		account            *common.Address
+		prevCode, prevHash []byte
+	}
+	storageChange struct {
+		account       *common.Address
+		key, prevalue common.Hash
+	}
+
+	// Changes to other state values.
+	refundChange struct {
+		prev uint64
+	}
+	addLogChange struct {
+		txhash common.Hash
+	}
+	addPreimageChange struct {
+		hash common.Hash
+	}
+	touchChange struct {
+		account *common.Address
+	}
+)
+
+func (ch createObjectChange) revert(s *Statedb) {
+	delete(s.stateObjects, *ch.account)
+}
+
+func (ch resetObjectChange) revert(s *Statedb) {
+	s.setStateObject(ch.prev)
+}
+
+func (ch suicideChange) revert(s *Statedb) {
+	obj := s.getStateObject(*ch.account)
+	if obj != nil {
+		obj.suicided = ch.prev
+		obj.setAmount(ch.prevAmount)
+	}
+}
+
+func (ch balanceChange) revert(s *Statedb) {
+	s.getStateObject(*ch.account).setAmount(ch.prev)
+}
+
+func (ch nonceChange) revert(s *Statedb) {
+	s.getStateObject(*ch.account).setNonce(ch.prev)
+}
+
+func (ch codeChange) revert(s *Statedb) {
+	s.getStateObject(*ch.account).setCode(common.BytesToHash(ch.prevHash), ch.prevCode)
+}
+
+func (ch storageChange) revert(s *Statedb) {
+	s.getStateObject(*ch.account).setState(ch.key, ch.prevalue)
+}
+
+func (ch refundChange) revert(s *Statedb) {
+	s.refund = ch.prev
+}
+
+func (ch addLogChange) revert(s *Statedb) {
+	logs := s.logs[ch.txhash]
+	if len(logs) == 1 {
+		delete(s.logs, ch.txhash)
+	} else {
+		s.logs[ch.txhash] = logs[:len(logs)-1]
+	}
+	s.logSize--
+}
+
+func (ch addPreimageChange) revert(s *Statedb) {
+	delete(s.preimages, ch.hash)
+}
+
+func (ch touchChange) revert(s *Statedb) {
+}
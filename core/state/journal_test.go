@@ -0,0 +1,90 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+func Test_Statedb_SnapshotRevert(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+
+	statedb.SetBalance(addr, big.NewInt(100))
+	statedb.SetNonce(addr, 1)
+
+	snapshot := statedb.Snapshot()
+
+	statedb.SetBalance(addr, big.NewInt(500))
+	statedb.SetNonce(addr, 2)
+	statedb.SetState(addr, common.BytesToHash([]byte{2}), common.BytesToHash([]byte{3}))
+
+	if got := statedb.GetBalance(addr); got.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("got balance %v before revert, want 500", got)
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if got := statedb.GetBalance(addr); got.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("got balance %v after revert, want 100", got)
+	}
+
+	if got := statedb.GetNonce(addr); got != 1 {
+		t.Fatalf("got nonce %v after revert, want 1", got)
+	}
+}
+
+func Test_Statedb_SuicideRevert(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+
+	statedb.SetBalance(addr, big.NewInt(100))
+
+	snapshot := statedb.Snapshot()
+
+	if !statedb.Suicide(addr) {
+		t.Fatal("expected Suicide to succeed for an existing account")
+	}
+
+	if !statedb.HasSuicided(addr) {
+		t.Fatal("expected HasSuicided to be true after Suicide")
+	}
+
+	if got := statedb.GetBalance(addr); got.Sign() != 0 {
+		t.Fatalf("got balance %v after suicide, want 0", got)
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if statedb.HasSuicided(addr) {
+		t.Fatal("expected HasSuicided to be false after revert")
+	}
+
+	if got := statedb.GetBalance(addr); got.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("got balance %v after revert, want 100", got)
+	}
+}
+
+func Test_Statedb_RefundRevert(t *testing.T) {
+	statedb := NewStatedb(nil)
+
+	statedb.AddRefund(10)
+	snapshot := statedb.Snapshot()
+	statedb.AddRefund(20)
+
+	if got := statedb.GetRefund(); got != 30 {
+		t.Fatalf("got refund %v before revert, want 30", got)
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if got := statedb.GetRefund(); got != 10 {
+		t.Fatalf("got refund %v after revert, want 10", got)
+	}
+}
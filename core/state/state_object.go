@@ -0,0 +1,247 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/trie"
+)
+
+// Account is the Ethereum consensus representation of an account stored in the state trie.
+type Account struct {
+	Nonce    uint64
+	Amount   *big.Int
+	CodeHash []byte
+	Root     common.Hash // storage trie root of the account
+}
+
+// stateObject represents an account that is being modified.
+//
+// The usage pattern is as follows:
+// - First you need to obtain a state object.
+// - Account values as well as storages can be accessed and modified through the object.
+// - Finally, call commit to write the modified storages into a trie.
+type stateObject struct {
+	address  common.Address
+	addrHash common.Hash // hash of address of the account
+	account  Account
+	db       *Statedb
+
+	// Write caches.
+	code []byte // contract bytecode, which gets set when code is loaded
+
+	storageTrie *trie.Trie // per-account storage trie, lazily opened and rooted at account.Root
+
+	originStorage map[common.Hash]common.Hash // storage cache of original entries to dedup rewrites
+	dirtyStorage  map[common.Hash]common.Hash // storage entries that have been modified in the current transaction execution
+
+	// Cache flags.
+	dirtyCode bool // true if the code was updated
+	suicided  bool
+	deleted   bool
+}
+
+// newObject creates a state object.
+func newObject(db *Statedb, address common.Address, account Account) *stateObject {
+	if account.Amount == nil {
+		account.Amount = new(big.Int)
+	}
+
+	if account.CodeHash == nil {
+		account.CodeHash = emptyCodeHash.Bytes()
+	}
+
+	return &stateObject{
+		db:            db,
+		address:       address,
+		addrHash:      common.BytesToHash(address[:]),
+		account:       account,
+		originStorage: make(map[common.Hash]common.Hash),
+		dirtyStorage:  make(map[common.Hash]common.Hash),
+	}
+}
+
+// empty returns whether the account is considered empty.
+func (s *stateObject) empty() bool {
+	return s.account.Nonce == 0 && s.account.Amount.Sign() == 0 && common.BytesToHash(s.account.CodeHash) == emptyCodeHash
+}
+
+// GetAmount returns the account balance.
+func (s *stateObject) GetAmount() *big.Int {
+	return s.account.Amount
+}
+
+// SetAmount sets the account balance, journalling the previous value so it can be restored on revert.
+func (s *stateObject) SetAmount(amount *big.Int) {
+	s.db.journal = append(s.db.journal, balanceChange{
+		account: &s.address,
+		prev:    new(big.Int).Set(s.account.Amount),
+	})
+	s.setAmount(amount)
+}
+
+func (s *stateObject) setAmount(amount *big.Int) {
+	s.account.Amount = amount
+}
+
+// GetNonce returns the account nonce.
+func (s *stateObject) GetNonce() uint64 {
+	return s.account.Nonce
+}
+
+// SetNonce sets the account nonce, journalling the previous value so it can be restored on revert.
+func (s *stateObject) SetNonce(nonce uint64) {
+	s.db.journal = append(s.db.journal, nonceChange{
+		account: &s.address,
+		prev:    s.account.Nonce,
+	})
+	s.setNonce(nonce)
+}
+
+func (s *stateObject) setNonce(nonce uint64) {
+	s.account.Nonce = nonce
+}
+
+// markSuicided flags the state object as suicided, without touching the journal.
+func (s *stateObject) markSuicided() {
+	s.suicided = true
+}
+
+// setCode replaces the contract code cached on the object, without touching the journal.
+func (s *stateObject) setCode(codeHash common.Hash, code []byte) {
+	s.code = code
+	s.account.CodeHash = codeHash.Bytes()
+	s.dirtyCode = true
+}
+
+// setState writes a storage slot into the dirty storage cache, without touching the journal.
+func (s *stateObject) setState(key, value common.Hash) {
+	s.dirtyStorage[key] = value
+}
+
+// Code returns the contract code associated with this account, lazily loading it from db
+// by its code hash on first access.
+func (s *stateObject) Code(db database.Database) []byte {
+	if s.code != nil {
+		return s.code
+	}
+
+	if common.BytesToHash(s.account.CodeHash) == emptyCodeHash {
+		return nil
+	}
+
+	code, err := db.Get(s.account.CodeHash)
+	if err != nil {
+		return nil
+	}
+
+	s.code = code
+
+	return code
+}
+
+// CodeSize returns the size of the contract code associated with this account.
+func (s *stateObject) CodeSize(db database.Database) int {
+	return len(s.Code(db))
+}
+
+// getTrie lazily opens the account's storage trie, rooted at the account's stored root.
+func (s *stateObject) getTrie(db database.Database) (*trie.Trie, error) {
+	if s.storageTrie == nil {
+		t, err := trie.NewTrie(s.account.Root, db)
+		if err != nil {
+			return nil, err
+		}
+
+		s.storageTrie = t
+	}
+
+	return s.storageTrie, nil
+}
+
+// GetState returns the value of the given storage key, checking the dirty and origin
+// caches before falling back to the storage trie.
+func (s *stateObject) GetState(db database.Database, key common.Hash) common.Hash {
+	if value, dirty := s.dirtyStorage[key]; dirty {
+		return value
+	}
+
+	if value, cached := s.originStorage[key]; cached {
+		return value
+	}
+
+	tr, err := s.getTrie(db)
+	if err != nil {
+		return common.EmptyHash
+	}
+
+	enc, err := tr.Get(key.Bytes())
+	if err != nil || len(enc) == 0 {
+		s.originStorage[key] = common.EmptyHash
+		return common.EmptyHash
+	}
+
+	value := common.BytesToHash(enc)
+	s.originStorage[key] = value
+
+	return value
+}
+
+// commitCode persists the contract code to db if it was modified since the last commit.
+func (s *stateObject) commitCode(db database.Database) error {
+	if !s.dirtyCode {
+		return nil
+	}
+
+	if err := db.Put(s.account.CodeHash, s.code); err != nil {
+		return err
+	}
+
+	s.dirtyCode = false
+
+	return nil
+}
+
+// commitStorageTrie flushes the dirty storage cache into the storage trie and updates the
+// account's storage root accordingly.
+func (s *stateObject) commitStorageTrie(db database.Database) error {
+	if len(s.dirtyStorage) == 0 {
+		return nil
+	}
+
+	tr, err := s.getTrie(db)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range s.dirtyStorage {
+		delete(s.dirtyStorage, key)
+		s.originStorage[key] = value
+
+		if value == common.EmptyHash {
+			if err := tr.Delete(key.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tr.Put(key.Bytes(), value.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	root, err := tr.Commit()
+	if err != nil {
+		return err
+	}
+
+	s.account.Root = root
+
+	return nil
+}
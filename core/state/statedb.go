@@ -0,0 +1,186 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database"
+)
+
+// emptyCodeHash is the code hash of an account that has no code.
+var emptyCodeHash = crypto.HashBytes(nil)
+
+// Statedb tracks all state changes that happen within a block, providing snapshot and
+// revert support so that failed EVM executions can roll back their partial side effects.
+type Statedb struct {
+	db database.Database // key/value store backing contract code and account storage tries
+
+	stateObjects map[common.Address]*stateObject
+
+	// The refund counter, also used by state transitioning.
+	refund uint64
+
+	logs    map[common.Hash][]*types.Log
+	logSize uint
+
+	preimages map[common.Hash][]byte
+
+	// thash/txIndex identify the transaction currently being executed, so that logs and
+	// preimages added during its execution can be attributed correctly.
+	thash   common.Hash
+	txIndex int
+
+	// journal contains the list of state modifications applied since the last state commit.
+	// These are tracked to be able to be reverted in case of an execution exception.
+	journal        []journalEntry
+	validRevisions []revision
+	nextRevisionID int
+}
+
+// revision associates a snapshot id with the journal length at the time the snapshot was taken.
+type revision struct {
+	id           int
+	journalIndex int
+}
+
+// NewStatedb creates a new empty Statedb backed by the given database, which is used to
+// persist contract code and account storage tries.
+func NewStatedb(db database.Database) *Statedb {
+	return &Statedb{
+		db:           db,
+		stateObjects: make(map[common.Address]*stateObject),
+		logs:         make(map[common.Hash][]*types.Log),
+		preimages:    make(map[common.Hash][]byte),
+	}
+}
+
+// getStateObject retrieves the state object of the given address, returning nil if not found.
+func (s *Statedb) getStateObject(address common.Address) *stateObject {
+	if obj, ok := s.stateObjects[address]; ok {
+		if obj.deleted {
+			return nil
+		}
+		return obj
+	}
+
+	return nil
+}
+
+// GetOrNewStateObject retrieves the state object of the given address, creating a new one if it does not exist.
+func (s *Statedb) GetOrNewStateObject(address common.Address) *stateObject {
+	obj := s.getStateObject(address)
+	if obj == nil {
+		obj = s.createObject(address)
+	}
+
+	return obj
+}
+
+// createObject creates a new state object for the given address, journalling the replacement of any
+// previous state object so both the creation and the prior state can be undone on revert. Any balance
+// held by a previous object (e.g. value sent to the address before its contract was deployed) carries
+// over to the new object; everything else (nonce, code, storage) starts from zero.
+func (s *Statedb) createObject(address common.Address) *stateObject {
+	prev := s.stateObjects[address]
+
+	var account Account
+	if prev != nil {
+		account.Amount = new(big.Int).Set(prev.GetAmount())
+	}
+
+	newObj := newObject(s, address, account)
+	if prev == nil {
+		s.journal = append(s.journal, createObjectChange{account: &address})
+	} else {
+		s.journal = append(s.journal, resetObjectChange{prev: prev})
+	}
+
+	s.setStateObject(newObj)
+
+	return newObj
+}
+
+func (s *Statedb) setStateObject(object *stateObject) {
+	s.stateObjects[object.address] = object
+}
+
+// GetNonce returns the nonce of the specified account if any. Otherwise, return 0.
+func (s *Statedb) GetNonce(address common.Address) uint64 {
+	if obj := s.getStateObject(address); obj != nil {
+		return obj.GetNonce()
+	}
+
+	return 0
+}
+
+// SetNonce sets the nonce of the specified account, creating the account if it does not exist.
+func (s *Statedb) SetNonce(address common.Address, nonce uint64) {
+	if obj := s.GetOrNewStateObject(address); obj != nil {
+		obj.SetNonce(nonce)
+	}
+}
+
+// GetBalance returns the balance of the specified account if any. Otherwise, return a zero value.
+func (s *Statedb) GetBalance(address common.Address) *big.Int {
+	if obj := s.getStateObject(address); obj != nil {
+		return obj.GetAmount()
+	}
+
+	return new(big.Int)
+}
+
+// SetBalance sets the balance of the specified account, creating the account if it does not exist.
+func (s *Statedb) SetBalance(address common.Address, amount *big.Int) {
+	if obj := s.GetOrNewStateObject(address); obj != nil {
+		obj.SetAmount(amount)
+	}
+}
+
+// Prepare sets the current transaction hash and index, which are used when the EVM adds
+// logs and preimages during that transaction's execution.
+func (s *Statedb) Prepare(thash common.Hash, txIndex int) {
+	s.thash = thash
+	s.txIndex = txIndex
+}
+
+// Commit flushes dirty contract code and storage tries to the database, then clears the
+// journal, since commited state can no longer be rolled back via RevertToSnapshot.
+func (s *Statedb) Commit() error {
+	for addr, obj := range s.stateObjects {
+		if obj.suicided {
+			delete(s.stateObjects, addr)
+			continue
+		}
+
+		if err := obj.commitCode(s.db); err != nil {
+			return err
+		}
+
+		if err := obj.commitStorageTrie(s.db); err != nil {
+			return err
+		}
+	}
+
+	s.clearJournal()
+
+	return nil
+}
+
+// Finalize clears the per-block journal and revision bookkeeping once a block has been processed
+// and there is no longer any need to be able to revert its state changes.
+func (s *Statedb) Finalize() {
+	s.clearJournal()
+}
+
+func (s *Statedb) clearJournal() {
+	s.journal = nil
+	s.validRevisions = s.validRevisions[:0]
+	s.nextRevisionID = 0
+}
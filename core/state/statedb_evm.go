@@ -6,64 +6,113 @@
 package state
 
 import (
+	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
 )
 
-// CreateAccount creates a new account in statedb.
+// CreateAccount creates a new account in statedb, replacing any existing object at address
+// (e.g. one left behind by a suicide earlier in the same block) rather than reusing it, so
+// the new contract starts with a fresh nonce, code and storage. Any balance already held at
+// address carries over, matching real-world value transfers that can arrive before a
+// contract is deployed to its address.
 func (s *Statedb) CreateAccount(address common.Address) {
-	s.GetOrNewStateObject(address)
+	s.createObject(address)
 }
 
 // GetCodeHash returns contract code hash associated with the specified address if any.
 // Otherwise, return an empty hash.
 func (s *Statedb) GetCodeHash(address common.Address) common.Hash {
-	// @todo
-	return common.EmptyHash
+	stateObj := s.getStateObject(address)
+	if stateObj == nil {
+		return common.EmptyHash
+	}
+
+	return common.BytesToHash(stateObj.account.CodeHash)
 }
 
 // GetCode returns the contract code associated with the specified address if any.
 // Otherwise, return nil.
 func (s *Statedb) GetCode(address common.Address) []byte {
-	// @todo
-	return nil
+	stateObj := s.getStateObject(address)
+	if stateObj == nil {
+		return nil
+	}
+
+	return stateObj.Code(s.db)
 }
 
 // SetCode sets the contract code of the specified address if exists.
 func (s *Statedb) SetCode(address common.Address, code []byte) {
-	// @todo
+	stateObj := s.GetOrNewStateObject(address)
+	if stateObj == nil {
+		return
+	}
+
+	s.journal = append(s.journal, codeChange{
+		account:  &address,
+		prevHash: stateObj.account.CodeHash,
+		prevCode: stateObj.Code(s.db),
+	})
+	stateObj.setCode(crypto.HashBytes(code), code)
 }
 
 // GetCodeSize returns the contract code size associated with the specified address if any.
 // Otherwise, return 0.
 func (s *Statedb) GetCodeSize(address common.Address) int {
-	// @todo
-	return 0
+	stateObj := s.getStateObject(address)
+	if stateObj == nil {
+		return 0
+	}
+
+	return stateObj.CodeSize(s.db)
 }
 
-// AddRefund refunds gas
+// AddRefund adds gas to the refund counter, journalling the previous value so it can be
+// restored on revert.
 func (s *Statedb) AddRefund(gas uint64) {
-	// @todo
+	s.journal = append(s.journal, refundChange{prev: s.refund})
+	s.refund += gas
 }
 
 // GetRefund returns the current value of the refund counter.
 func (s *Statedb) GetRefund() uint64 {
-	// @todo
-	return 0
+	return s.refund
 }
 
 // GetState returns the value of specified key in account storage if exists.
 // Otherwise, return empty hash.
 func (s *Statedb) GetState(address common.Address, key common.Hash) common.Hash {
-	// @todo
-	return common.EmptyHash
+	stateObj := s.getStateObject(address)
+	if stateObj == nil {
+		return common.EmptyHash
+	}
+
+	return stateObj.GetState(s.db, key)
 }
 
 // SetState adds or updates key-value pair in account storage.
 func (s *Statedb) SetState(address common.Address, key common.Hash, value common.Hash) {
-	// @todo
+	stateObj := s.GetOrNewStateObject(address)
+	if stateObj == nil {
+		return
+	}
+
+	prev := stateObj.GetState(s.db, key)
+	if prev == value {
+		return
+	}
+
+	s.journal = append(s.journal, storageChange{
+		account:  &address,
+		key:      key,
+		prevalue: prev,
+	})
+	stateObj.setState(key, value)
 }
 
 // Suicide marks the given account as suicided and clears the account balance.
@@ -75,8 +124,14 @@ func (s *Statedb) Suicide(address common.Address) bool {
 		return false
 	}
 
-	stateObj.SetAmount(new(big.Int))
-	// @todo mark the state object as suicided
+	s.journal = append(s.journal, suicideChange{
+		account:    &address,
+		prev:       stateObj.suicided,
+		prevAmount: new(big.Int).Set(stateObj.GetAmount()),
+	})
+
+	stateObj.markSuicided()
+	stateObj.setAmount(new(big.Int))
 
 	return true
 }
@@ -88,9 +143,7 @@ func (s *Statedb) HasSuicided(address common.Address) bool {
 		return false
 	}
 
-	// @todo return stateObj.suicided
-
-	return false
+	return stateObj.suicided
 }
 
 // Exist reports whether the given account exists in state.
@@ -101,32 +154,95 @@ func (s *Statedb) Exist(address common.Address) bool {
 
 // Empty returns whether the given account satisfy (balance = nonce = code = 0).
 func (s *Statedb) Empty(address common.Address) bool {
-	// @todo
-	return false
+	stateObj := s.getStateObject(address)
+	return stateObj == nil || stateObj.empty()
 }
 
 // RevertToSnapshot reverts all state changes made since the given revision.
 func (s *Statedb) RevertToSnapshot(revid int) {
-	// @todo
+	// Find the snapshot in the stack of valid snapshots.
+	idx := sort.Search(len(s.validRevisions), func(i int) bool {
+		return s.validRevisions[i].id >= revid
+	})
+	if idx == len(s.validRevisions) || s.validRevisions[idx].id != revid {
+		panic(fmt.Errorf("revision id %v cannot be reverted", revid))
+	}
+	snapshot := s.validRevisions[idx].journalIndex
+
+	// Replay the journal to undo changes and remove invalidated snapshots.
+	for i := len(s.journal) - 1; i >= snapshot; i-- {
+		s.journal[i].revert(s)
+	}
+	s.journal = s.journal[:snapshot]
+	s.validRevisions = s.validRevisions[:idx]
 }
 
 // Snapshot returns an identifier for the current revision of the state.
 func (s *Statedb) Snapshot() int {
-	// @todo
-	return 0
+	id := s.nextRevisionID
+	s.nextRevisionID++
+	s.validRevisions = append(s.validRevisions, revision{id, len(s.journal)})
+
+	return id
 }
 
 // AddLog add a log.
 func (s *Statedb) AddLog(log *types.Log) {
-	// @todo
+	s.journal = append(s.journal, addLogChange{txhash: s.thash})
+
+	log.TxHash = s.thash
+	log.TxIndex = uint(s.txIndex)
+	s.logs[s.thash] = append(s.logs[s.thash], log)
+	s.logSize++
+}
+
+// GetLogs returns the logs generated while executing the transaction identified by hash.
+func (s *Statedb) GetLogs(hash common.Hash) []*types.Log {
+	return s.logs[hash]
 }
 
 // AddPreimage records a SHA3 preimage seen by the VM.
-func (s *Statedb) AddPreimage(common.Hash, []byte) {
-	// @todo
+func (s *Statedb) AddPreimage(hash common.Hash, preimage []byte) {
+	if _, ok := s.preimages[hash]; !ok {
+		s.journal = append(s.journal, addPreimageChange{hash: hash})
+
+		cp := make([]byte, len(preimage))
+		copy(cp, preimage)
+		s.preimages[hash] = cp
+	}
+}
+
+// Preimages returns a list of SHA3 preimages that have been submitted.
+func (s *Statedb) Preimages() map[common.Hash][]byte {
+	return s.preimages
 }
 
 // ForEachStorage visits all the key-value pairs for the specified account storage.
-func (s *Statedb) ForEachStorage(common.Address, func(common.Hash, common.Hash) bool) {
-	// @todo
-}
\ No newline at end of file
+// Only entries held in the account's in-memory storage caches (read or written since the
+// object was loaded) are visited; entries that exist only in the storage trie are not.
+func (s *Statedb) ForEachStorage(address common.Address, cb func(common.Hash, common.Hash) bool) {
+	stateObj := s.getStateObject(address)
+	if stateObj == nil {
+		return
+	}
+
+	for key, value := range stateObj.originStorage {
+		if dirty, ok := stateObj.dirtyStorage[key]; ok {
+			value = dirty
+		}
+
+		if !cb(key, value) {
+			return
+		}
+	}
+
+	for key, value := range stateObj.dirtyStorage {
+		if _, ok := stateObj.originStorage[key]; ok {
+			continue
+		}
+
+		if !cb(key, value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// memDatabase is a minimal in-memory database.Database used to back a storage trie in tests.
+type memDatabase struct {
+	data map[string][]byte
+}
+
+func newMemDatabase() *memDatabase {
+	return &memDatabase{data: make(map[string][]byte)}
+}
+
+func (db *memDatabase) Get(key []byte) ([]byte, error) {
+	value, ok := db.data[string(key)]
+	if !ok {
+		return nil, errors.New("memDatabase: key not found")
+	}
+
+	return value, nil
+}
+
+func (db *memDatabase) Put(key, value []byte) error {
+	db.data[string(key)] = value
+	return nil
+}
+
+func Test_Statedb_CodeRevert(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+	code := []byte{0x60, 0x01, 0x60, 0x02, 0x01}
+
+	snapshot := statedb.Snapshot()
+
+	statedb.SetCode(addr, code)
+	if got := statedb.GetCode(addr); !bytes.Equal(got, code) {
+		t.Fatalf("got code %x before revert, want %x", got, code)
+	}
+	if statedb.GetCodeHash(addr) == emptyCodeHash {
+		t.Fatal("expected a non-empty code hash after SetCode")
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if got := statedb.GetCode(addr); got != nil {
+		t.Fatalf("got code %x after revert, want nil", got)
+	}
+	if got := statedb.GetCodeHash(addr); got != common.EmptyHash {
+		t.Fatalf("got code hash %v after revert, want empty hash", got)
+	}
+}
+
+func Test_Statedb_StorageRevert(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+	key := common.BytesToHash([]byte{2})
+	value := common.BytesToHash([]byte{3})
+
+	snapshot := statedb.Snapshot()
+
+	statedb.SetState(addr, key, value)
+	if got := statedb.GetState(addr, key); got != value {
+		t.Fatalf("got state %v before revert, want %v", got, value)
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if got := statedb.GetState(addr, key); got != common.EmptyHash {
+		t.Fatalf("got state %v after revert, want empty hash", got)
+	}
+}
+
+func Test_Statedb_StorageRevertAfterCommit(t *testing.T) {
+	statedb := NewStatedb(newMemDatabase())
+	addr := common.BytesToAddress([]byte{1})
+	key := common.BytesToHash([]byte{2})
+	committed := common.BytesToHash([]byte{3})
+	updated := common.BytesToHash([]byte{4})
+
+	statedb.SetState(addr, key, committed)
+	if err := statedb.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	snapshot := statedb.Snapshot()
+
+	statedb.SetState(addr, key, updated)
+	if got := statedb.GetState(addr, key); got != updated {
+		t.Fatalf("got state %v before revert, want %v", got, updated)
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if got := statedb.GetState(addr, key); got != committed {
+		t.Fatalf("got state %v after revert, want committed value %v", got, committed)
+	}
+}
+
+func Test_Statedb_CreateAccount_ResetsSuicidedObject(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+
+	statedb.SetNonce(addr, 7)
+	statedb.SetCode(addr, []byte{0x60, 0x01})
+	statedb.SetBalance(addr, big.NewInt(42))
+	statedb.Suicide(addr)
+
+	statedb.CreateAccount(addr)
+
+	if got := statedb.GetNonce(addr); got != 0 {
+		t.Fatalf("got nonce %d after CreateAccount, want 0", got)
+	}
+	if got := statedb.GetCode(addr); got != nil {
+		t.Fatalf("got code %x after CreateAccount, want nil", got)
+	}
+}
+
+func Test_Statedb_CreateAccount_CarriesOverBalance(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+
+	statedb.SetBalance(addr, big.NewInt(42))
+
+	statedb.CreateAccount(addr)
+
+	if got := statedb.GetBalance(addr); got.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("got balance %v after CreateAccount, want 42", got)
+	}
+}
+
+func Test_Statedb_CreateAccount_RevertRestoresPreviousObject(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+
+	statedb.SetNonce(addr, 7)
+
+	snapshot := statedb.Snapshot()
+
+	statedb.CreateAccount(addr)
+	if got := statedb.GetNonce(addr); got != 0 {
+		t.Fatalf("got nonce %d after CreateAccount, want 0", got)
+	}
+
+	statedb.RevertToSnapshot(snapshot)
+
+	if got := statedb.GetNonce(addr); got != 7 {
+		t.Fatalf("got nonce %d after revert, want 7", got)
+	}
+}
+
+func Test_Statedb_Empty(t *testing.T) {
+	statedb := NewStatedb(nil)
+	addr := common.BytesToAddress([]byte{1})
+
+	if !statedb.Empty(addr) {
+		t.Fatal("expected a non-existent account to be empty")
+	}
+
+	statedb.SetBalance(addr, big.NewInt(1))
+	if statedb.Empty(addr) {
+		t.Fatal("expected an account with a non-zero balance to not be empty")
+	}
+}
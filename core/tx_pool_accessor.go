@@ -0,0 +1,66 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"sort"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+// GetPendingAndQueued splits the transactions currently held in the pool into two buckets:
+// pending transactions, which are executable next given the sender's current account nonce,
+// and queued transactions, whose nonce leaves a gap and so cannot be processed yet. Both
+// buckets are grouped by sender address and then by nonce, matching the shape the txpool RPC
+// namespace exposes.
+func (pool *TxPool) GetPendingAndQueued() (pending, queued map[common.Address]map[uint64]*types.Transaction) {
+	pending = make(map[common.Address]map[uint64]*types.Transaction)
+	queued = make(map[common.Address]map[uint64]*types.Transaction)
+
+	state := pool.chain.GetCurrentState()
+
+	for address, txs := range pool.getTransactions() {
+		currentNonce := state.GetNonce(address)
+
+		addrPending, addrQueued := classifyByNonce(txs, currentNonce)
+		if len(addrPending) > 0 {
+			pending[address] = addrPending
+		}
+		if len(addrQueued) > 0 {
+			queued[address] = addrQueued
+		}
+	}
+
+	return pending, queued
+}
+
+// classifyByNonce splits a single account's transactions into pending (executable next,
+// starting at currentNonce with no gaps) and queued (nonce leaves a gap) buckets. A copy of
+// txs is sorted by nonce ascending first, since the split walks them in order and only
+// advances currentNonce when a tx's nonce matches it exactly; the caller's slice is not reordered.
+func classifyByNonce(txs []*types.Transaction, currentNonce uint64) (pending, queued map[uint64]*types.Transaction) {
+	sorted := make([]*types.Transaction, len(txs))
+	copy(sorted, txs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Data.AccountNonce < sorted[j].Data.AccountNonce
+	})
+
+	pending = make(map[uint64]*types.Transaction)
+	queued = make(map[uint64]*types.Transaction)
+
+	for _, tx := range sorted {
+		bucket := queued
+		if tx.Data.AccountNonce == currentNonce {
+			bucket = pending
+			currentNonce++
+		}
+
+		bucket[tx.Data.AccountNonce] = tx
+	}
+
+	return pending, queued
+}
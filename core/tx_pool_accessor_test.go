@@ -0,0 +1,55 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+func Test_ClassifyByNonce_Unordered(t *testing.T) {
+	tx := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{Data: types.TransactionData{AccountNonce: nonce}}
+	}
+
+	// Deliberately out of nonce order: 6 arrives before 5, which would misclassify 6 as
+	// queued if the split didn't sort by nonce first.
+	txs := []*types.Transaction{tx(6), tx(5), tx(7)}
+
+	pending, queued := classifyByNonce(txs, 5)
+
+	if len(pending) != 3 {
+		t.Fatalf("got %d pending, want 3", len(pending))
+	}
+	for _, nonce := range []uint64{5, 6, 7} {
+		if pending[nonce] == nil {
+			t.Fatalf("expected nonce %d to be pending", nonce)
+		}
+	}
+	if len(queued) != 0 {
+		t.Fatalf("got %d queued, want 0", len(queued))
+	}
+}
+
+func Test_ClassifyByNonce_Gap(t *testing.T) {
+	tx := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{Data: types.TransactionData{AccountNonce: nonce}}
+	}
+
+	// Nonce 6 is missing, so 7 and 8 must be queued even though they arrive in order.
+	txs := []*types.Transaction{tx(5), tx(7), tx(8)}
+
+	pending, queued := classifyByNonce(txs, 5)
+
+	if len(pending) != 1 || pending[5] == nil {
+		t.Fatalf("got pending %v, want only nonce 5", pending)
+	}
+
+	if len(queued) != 2 || queued[7] == nil || queued[8] == nil {
+		t.Fatalf("got queued %v, want nonces 7 and 8", queued)
+	}
+}
@@ -0,0 +1,52 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import "github.com/seeleteam/go-seele/p2p"
+
+// Config holds the configuration used to create a Node, covering the p2p server, the data
+// directory shared by every service's database, and the four RPC transports (raw TCP, HTTP,
+// WebSocket, IPC).
+type Config struct {
+	// P2P is the configuration handed to the p2p server started alongside the node's services.
+	P2P p2p.Config
+
+	// NetworkID identifies the network the node participates in (e.g. mainnet vs. a
+	// testnet), so services that report on the node can tell clients which network they're
+	// looking at.
+	NetworkID uint64
+
+	// DataDir is the filesystem directory under which service databases are rooted.
+	DataDir string
+
+	// RPCAddr is the listen address for the raw TCP JSON-RPC endpoint.
+	RPCAddr string
+
+	// HTTPAddr is the listen address for the HTTP JSON-RPC endpoint.
+	HTTPAddr string
+
+	// HTTPWhiteHost lists the virtual hosts the HTTP endpoint will serve. An empty list allows any host.
+	HTTPWhiteHost []string
+
+	// HTTPCors lists the origins allowed to make cross-origin requests against the HTTP endpoint.
+	HTTPCors []string
+
+	// WSAddr is the listen address for the WebSocket JSON-RPC endpoint. Leaving it empty disables WS.
+	WSAddr string
+
+	// WSOrigins lists the origins allowed to open a WebSocket connection. An empty list allows any origin.
+	WSOrigins []string
+
+	// WSModules restricts the RPC namespaces exposed over WebSocket. An empty list exposes every namespace.
+	WSModules []string
+
+	// IPCPath is the filesystem path of the IPC endpoint's Unix domain socket (or named pipe on
+	// Windows). Leaving it empty disables IPC.
+	IPCPath string
+
+	// IPCModules restricts the RPC namespaces exposed over IPC. An empty list exposes every namespace.
+	IPCModules []string
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+// +build !windows
+
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import (
+	"net"
+	"os"
+)
+
+// ipcListen opens a Unix domain socket at endpoint for the IPC RPC endpoint, removing any
+// stale socket file left behind by a previous, uncleanly terminated run.
+func ipcListen(endpoint string) (net.Listener, error) {
+	if err := os.Remove(endpoint); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	os.Chmod(endpoint, 0600)
+
+	return listener, nil
+}
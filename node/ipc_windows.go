@@ -0,0 +1,21 @@
+//go:build windows
+// +build windows
+
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import (
+	"net"
+
+	"gopkg.in/natefinch/npipe.v2"
+)
+
+// ipcListen opens a named pipe at endpoint for the IPC RPC endpoint on Windows, where Unix
+// domain sockets are not available.
+func ipcListen(endpoint string) (net.Listener, error) {
+	return npipe.Listen(endpoint)
+}
@@ -14,7 +14,10 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/gorilla/websocket"
 	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/event"
 	"github.com/seeleteam/go-seele/log"
 	"github.com/seeleteam/go-seele/p2p"
 	"github.com/seeleteam/go-seele/rpc"
@@ -28,6 +31,7 @@ var (
 	ErrNodeStopped        = errors.New("node is not started")
 	ErrServiceStartFailed = errors.New("node service starting failed")
 	ErrServiceStopFailed  = errors.New("node service stopping failed")
+	ErrServiceUnknown     = errors.New("no constructed service matches the requested type")
 )
 
 // StopError represents an error which is returned when a node fails to stop any registered service
@@ -47,7 +51,22 @@ type Node struct {
 	serverConfig p2p.Config
 	server       *p2p.Server
 
-	services []Service
+	// serviceConstructors accumulates the constructors registered before Start; they are
+	// consumed by buildServices to build the running services in registration order.
+	serviceConstructors []ServiceConstructor
+	services            []Service
+
+	eventMux *event.EventMux
+
+	// listeners for the four RPC transports, tracked so Stop can shut them down cleanly.
+	rpcListener  net.Listener
+	httpListener net.Listener
+	wsListener   net.Listener
+	ipcListener  net.Listener
+
+	// httpHandlers holds the extra handlers registered via RegisterHandler, mounted
+	// alongside the JSON-RPC handler when startHTTPRPC builds its mux.
+	httpHandlers []httpHandlerRegistration
 
 	rpcAPIs []rpc.API
 
@@ -63,24 +82,93 @@ func New(conf *Config) (*Node, error) {
 
 	return &Node{
 		config:   conf,
-		services: []Service{},
+		eventMux: new(event.EventMux),
 		log:      nlog,
 	}, nil
 }
 
-// Register appends a new service into the node's stack.
-func (n *Node) Register(service Service) error {
+// Register appends a service constructor to the node's stack. The service itself is not
+// built until Start runs, at which point it receives a ServiceContext that can look up any
+// service registered before it.
+//
+// Register used to take an already-constructed Service; callers that still build their
+// service up front must wrap it in a constructor, e.g.
+// node.Register(func(ctx *node.ServiceContext) (node.Service, error) { return myService, nil }).
+func (n *Node) Register(constructor ServiceConstructor) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.server != nil {
+		return ErrNodeRunning
+	}
+	n.serviceConstructors = append(n.serviceConstructors, constructor)
+
+	return nil
+}
+
+// httpHandlerRegistration is one entry registered via RegisterHandler.
+type httpHandlerRegistration struct {
+	name    string
+	path    string
+	handler http.Handler
+}
+
+// RegisterHandler mounts an arbitrary HTTP handler at path on the node's user-facing HTTP
+// server, alongside the JSON-RPC handler it serves at "/". This lets callers add endpoints
+// such as GraphQL, a Prometheus /metrics scrape target, or a /health liveness probe without
+// forking the node package. It must be called before Start; the handler inherits the same
+// HTTPWhiteHost/HTTPCors middleware as the JSON-RPC handler.
+func (n *Node) RegisterHandler(name, path string, handler http.Handler) error {
 	n.lock.Lock()
 	defer n.lock.Unlock()
 
 	if n.server != nil {
 		return ErrNodeRunning
 	}
-	n.services = append(n.services, service)
+
+	if path == "/" {
+		return fmt.Errorf("path %q is reserved for the JSON-RPC handler", path)
+	}
+
+	for _, existing := range n.httpHandlers {
+		if existing.path == path {
+			return fmt.Errorf("a handler is already registered for path %q", path)
+		}
+	}
+
+	n.httpHandlers = append(n.httpHandlers, httpHandlerRegistration{name, path, handler})
 
 	return nil
 }
 
+// buildServices constructs every registered service in registration order, threading a
+// single ServiceContext through all of them so a later service can look up an earlier one.
+func (n *Node) buildServices() ([]Service, error) {
+	ctx := &ServiceContext{
+		config:    n.config,
+		services:  make(map[reflect.Type]Service),
+		databases: make(map[string]database.Database),
+		eventMux:  n.eventMux,
+	}
+
+	services := make([]Service, 0, len(n.serviceConstructors))
+	for _, constructor := range n.serviceConstructors {
+		service, err := constructor(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		kind := reflect.TypeOf(service)
+		if _, dup := ctx.services[kind]; dup {
+			return nil, fmt.Errorf("duplicate service of type %v", kind)
+		}
+		ctx.services[kind] = service
+		services = append(services, service)
+	}
+
+	return services, nil
+}
+
 // Start starts the p2p node.
 func (n *Node) Start() error {
 	n.lock.Lock()
@@ -90,6 +178,12 @@ func (n *Node) Start() error {
 		return ErrNodeRunning
 	}
 
+	services, err := n.buildServices()
+	if err != nil {
+		return err
+	}
+	n.services = services
+
 	n.serverConfig = n.config.P2P
 	running := &p2p.Server{Config: n.serverConfig}
 	for _, service := range n.services {
@@ -116,6 +210,8 @@ func (n *Node) Start() error {
 
 	// Start RPC server
 	if err := n.startRPC(n.services, n.config); err != nil {
+		n.stopRPC()
+
 		for _, service := range n.services {
 			service.Stop()
 		}
@@ -148,20 +244,73 @@ func (n *Node) startRPC(services []Service, conf *Config) error {
 		return err
 	}
 
+	if conf.WSAddr != "" {
+		if err := n.startWSRPC(apis, conf.WSOrigins, conf.WSModules); err != nil {
+			n.log.Error("starting ws rpc failed", err)
+			return err
+		}
+	}
+
+	if conf.IPCPath != "" {
+		if err := n.startIPCRPC(apis, conf.IPCModules); err != nil {
+			n.log.Error("starting ipc rpc failed", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
-// startJSONRPC starts the json rpc server
-func (n *Node) startJSONRPC(apis []rpc.API) error {
-	handler := rpc.NewServer()
+// stopRPC closes every RPC listener that was opened by startRPC. Closing a listener also
+// unblocks its Accept loop, so the goroutines spawned in startJSONRPC/startHTTPRPC/
+// startWSRPC/startIPCRPC exit on their own.
+func (n *Node) stopRPC() {
+	for _, listener := range []net.Listener{n.rpcListener, n.httpListener, n.wsListener, n.ipcListener} {
+		if listener != nil {
+			listener.Close()
+		}
+	}
+
+	n.rpcListener = nil
+	n.httpListener = nil
+	n.wsListener = nil
+	n.ipcListener = nil
+}
+
+// registerAPIs registers the given apis on server, restricting registration to the
+// namespaces listed in modules. A nil or empty modules list registers every namespace,
+// which preserves the historical, unfiltered behavior of the raw JSON-RPC and HTTP endpoints.
+func (n *Node) registerAPIs(server *rpc.Server, apis []rpc.API, modules []string) error {
+	var whitelist map[string]bool
+	if len(modules) > 0 {
+		whitelist = make(map[string]bool, len(modules))
+		for _, module := range modules {
+			whitelist[module] = true
+		}
+	}
+
 	for _, api := range apis {
-		if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+		if whitelist != nil && !whitelist[api.Namespace] {
+			continue
+		}
+
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
 			n.log.Error("Api registration failed", "service", api.Service, "namespace", api.Namespace)
 			return err
 		}
 		n.log.Debug("registered service namespace: %s", api.Namespace)
 	}
 
+	return nil
+}
+
+// startJSONRPC starts the json rpc server
+func (n *Node) startJSONRPC(apis []rpc.API) error {
+	handler := rpc.NewServer()
+	if err := n.registerAPIs(handler, apis, nil); err != nil {
+		return err
+	}
+
 	var (
 		listerner net.Listener
 		err       error
@@ -171,14 +320,14 @@ func (n *Node) startJSONRPC(apis []rpc.API) error {
 		n.log.Error("Listening failed", "err", err)
 		return err
 	}
+	n.rpcListener = listerner
 
 	n.log.Debug("Listerner address %s", listerner.Addr().String())
 	go func() {
 		for {
 			conn, err := listerner.Accept()
 			if err != nil {
-				n.log.Error("RPC accepting failed", "err", err)
-				continue
+				return
 			}
 			go handler.ServeCodec(rpc.NewJsonCodec(conn))
 		}
@@ -187,28 +336,116 @@ func (n *Node) startJSONRPC(apis []rpc.API) error {
 	return nil
 }
 
-// startHTTPRPC starts the http rpc server
+// startHTTPRPC starts the http rpc server. The JSON-RPC handler is mounted at "/", and any
+// handler registered via RegisterHandler is mounted alongside it at its own path, wrapped in
+// the same HTTPWhiteHost/HTTPCors middleware so it inherits the node's vhost and CORS policy.
 func (n *Node) startHTTPRPC(apis []rpc.API, whitehosts []string, corsList []string) error {
 	httpServer, httpHandler := rpc.NewHTTPServer(whitehosts, corsList)
-	for _, api := range apis {
-		if err := httpServer.RegisterName(api.Namespace, api.Service); err != nil {
-			n.log.Error("Api registration failed", "service", api.Service, "namespace", api.Namespace)
-			return err
-		}
-		n.log.Debug("registered service namespace: %s", api.Namespace)
+	if err := n.registerAPIs(httpServer, apis, nil); err != nil {
+		return err
+	}
+	httpServer.HandleHTTP(netrpc.DefaultRPCPath, netrpc.DefaultDebugPath)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", httpHandler)
+	for _, reg := range n.httpHandlers {
+		mux.Handle(reg.path, rpc.NewHTTPHandlerStack(reg.handler, corsList, whitehosts))
+		n.log.Debug("registered http handler: %s at %s", reg.name, reg.path)
 	}
 
 	var (
 		listerner net.Listener
 		err       error
 	)
-	httpServer.HandleHTTP(netrpc.DefaultRPCPath, netrpc.DefaultDebugPath)
 	if listerner, err = net.Listen("tcp", n.config.HTTPAddr); err != nil {
 		n.log.Error("HTTP listening failed", "err", err)
 		return err
 	}
+	n.httpListener = listerner
+
+	go http.Serve(listerner, mux)
+
+	return nil
+}
+
+// startWSRPC starts the websocket rpc endpoint, upgrading incoming HTTP connections and
+// serving each one as an independent JSON-RPC codec.
+func (n *Node) startWSRPC(apis []rpc.API, origins []string, modules []string) error {
+	handler := rpc.NewServer()
+	if err := n.registerAPIs(handler, apis, modules); err != nil {
+		return err
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     wsOriginChecker(origins),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			n.log.Error("WS upgrade failed", "err", err)
+			return
+		}
+
+		go handler.ServeCodec(rpc.NewJsonCodec(&wsMessageConn{Conn: conn}))
+	})
+
+	listerner, err := net.Listen("tcp", n.config.WSAddr)
+	if err != nil {
+		n.log.Error("WS listening failed", "err", err)
+		return err
+	}
+	n.wsListener = listerner
 
-	go http.Serve(listerner, httpHandler)
+	go http.Serve(listerner, mux)
+
+	return nil
+}
+
+// wsOriginChecker builds the CheckOrigin callback used by the websocket upgrader. An empty
+// origins list allows every origin, matching the permissive default of the other endpoints.
+func wsOriginChecker(origins []string) func(r *http.Request) bool {
+	if len(origins) == 0 {
+		return func(r *http.Request) bool { return true }
+	}
+
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+
+	return func(r *http.Request) bool {
+		return allowed[r.Header.Get("Origin")]
+	}
+}
+
+// startIPCRPC starts the IPC endpoint, listening on a Unix domain socket (or a named pipe
+// on Windows) and serving the same JSON codec as the raw TCP endpoint.
+func (n *Node) startIPCRPC(apis []rpc.API, modules []string) error {
+	handler := rpc.NewServer()
+	if err := n.registerAPIs(handler, apis, modules); err != nil {
+		return err
+	}
+
+	listerner, err := ipcListen(n.config.IPCPath)
+	if err != nil {
+		n.log.Error("IPC listening failed", "err", err)
+		return err
+	}
+	n.ipcListener = listerner
+
+	go func() {
+		for {
+			conn, err := listerner.Accept()
+			if err != nil {
+				return
+			}
+			go handler.ServeCodec(rpc.NewJsonCodec(conn))
+		}
+	}()
 
 	return nil
 }
@@ -236,6 +473,9 @@ func (n *Node) Stop() error {
 	// stop the p2p server
 	n.server.Stop()
 
+	// close the RPC listeners, which also stops their accept loops
+	n.stopRPC()
+
 	n.services = nil
 	n.server = nil
 
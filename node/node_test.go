@@ -0,0 +1,55 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// fakeNamespaceAPI is a minimal RPC service used to exercise registerAPIs's module
+// whitelist without needing a real seele/seelestats service.
+type fakeNamespaceAPI struct{}
+
+func (*fakeNamespaceAPI) Echo(in string, out *string) error {
+	*out = in
+	return nil
+}
+
+func Test_Node_RegisterAPIs_NilModulesRegistersEveryNamespace(t *testing.T) {
+	n, err := New(&Config{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	apis := []rpc.API{
+		{Namespace: "foo", Service: new(fakeNamespaceAPI)},
+		{Namespace: "bar", Service: new(fakeNamespaceAPI)},
+	}
+
+	if err := n.registerAPIs(rpc.NewServer(), apis, nil); err != nil {
+		t.Fatalf("registerAPIs returned error: %v", err)
+	}
+}
+
+func Test_Node_RegisterAPIs_WhitelistSkipsUnlistedNamespaces(t *testing.T) {
+	n, err := New(&Config{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	// Both entries share a namespace; registering the same namespace twice on one server
+	// fails, so this only succeeds if the whitelist skips both before they reach the server.
+	apis := []rpc.API{
+		{Namespace: "dup", Service: new(fakeNamespaceAPI)},
+		{Namespace: "dup", Service: new(fakeNamespaceAPI)},
+	}
+
+	if err := n.registerAPIs(rpc.NewServer(), apis, []string{"other"}); err != nil {
+		t.Fatalf("registerAPIs returned error: %v, want the whitelist to skip both entries", err)
+	}
+}
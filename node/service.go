@@ -0,0 +1,97 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import (
+	"path/filepath"
+	"reflect"
+
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/event"
+	"github.com/seeleteam/go-seele/p2p"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// Service is implemented by any backend that wants to be driven by the node's lifecycle,
+// contributing p2p protocols and RPC APIs.
+type Service interface {
+	// Protocols returns the p2p protocols the service wishes to run.
+	Protocols() []p2p.Protocol
+
+	// APIs returns the RPC APIs the service wants to expose.
+	APIs() []rpc.API
+
+	// Start is called after the p2p server has been constructed, and is used to start any
+	// goroutines required by the service.
+	Start(server *p2p.Server) error
+
+	// Stop terminates all goroutines belonging to the service and cleans up resources.
+	Stop() error
+}
+
+// ServiceConstructor builds a Service out of a ServiceContext. Constructors run in
+// registration order during Node.Start, so a later service can depend on an earlier one
+// through ServiceContext.Service.
+type ServiceConstructor func(ctx *ServiceContext) (Service, error)
+
+// ServiceContext is handed to every ServiceConstructor during Node.Start. It exposes the
+// node configuration, a deduped database opener, the shared event mux, and a lookup for
+// services that have already been constructed.
+type ServiceContext struct {
+	config *Config
+
+	services map[reflect.Type]Service
+
+	databases map[string]database.Database
+
+	eventMux *event.EventMux
+}
+
+// OpenDatabase opens (or returns the already-open) key/value database of the given name,
+// rooted under the node's data directory. Repeated calls for the same name return the same
+// instance, so multiple services can safely share one database.
+func (ctx *ServiceContext) OpenDatabase(name string, cache int) (database.Database, error) {
+	if db, ok := ctx.databases[name]; ok {
+		return db, nil
+	}
+
+	path := filepath.Join(ctx.config.DataDir, name)
+	db, err := leveldb.NewLevelDB(path, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.databases[name] = db
+
+	return db, nil
+}
+
+// EventMux returns the event multiplexer shared across all services in the node.
+func (ctx *ServiceContext) EventMux() *event.EventMux {
+	return ctx.eventMux
+}
+
+// Config returns the node configuration the service stack was created with.
+func (ctx *ServiceContext) Config() *Config {
+	return ctx.config
+}
+
+// Service retrieves an already-constructed service of the given type and assigns it to
+// target, which must be a pointer to a Service-implementing type (e.g. **SeeleService).
+// It returns ErrServiceUnknown if no matching service has been registered yet.
+func (ctx *ServiceContext) Service(target interface{}) error {
+	targetVal := reflect.ValueOf(target).Elem()
+
+	for _, service := range ctx.services {
+		if reflect.TypeOf(service).AssignableTo(targetVal.Type()) {
+			targetVal.Set(reflect.ValueOf(service))
+			return nil
+		}
+	}
+
+	return ErrServiceUnknown
+}
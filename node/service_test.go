@@ -0,0 +1,101 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/seeleteam/go-seele/p2p"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// fakeServiceA is a minimal Service implementation used to exercise ServiceContext.Service
+// and buildServices without needing a real p2p/rpc stack.
+type fakeServiceA struct{}
+
+func (*fakeServiceA) Protocols() []p2p.Protocol { return nil }
+func (*fakeServiceA) APIs() []rpc.API           { return nil }
+func (*fakeServiceA) Start(*p2p.Server) error   { return nil }
+func (*fakeServiceA) Stop() error               { return nil }
+
+func Test_ServiceContext_Service_FindsEarlierService(t *testing.T) {
+	a := &fakeServiceA{}
+
+	ctx := &ServiceContext{
+		services: map[reflect.Type]Service{
+			reflect.TypeOf(a): a,
+		},
+	}
+
+	var found *fakeServiceA
+	if err := ctx.Service(&found); err != nil {
+		t.Fatalf("Service returned error: %v", err)
+	}
+	if found != a {
+		t.Fatalf("got %v, want %v", found, a)
+	}
+}
+
+func Test_ServiceContext_Service_Unknown(t *testing.T) {
+	ctx := &ServiceContext{
+		services: map[reflect.Type]Service{},
+	}
+
+	var found *fakeServiceA
+	if err := ctx.Service(&found); err != ErrServiceUnknown {
+		t.Fatalf("got error %v, want ErrServiceUnknown", err)
+	}
+}
+
+func Test_Node_BuildServices_DuplicateType(t *testing.T) {
+	n, err := New(&Config{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	ctor := func(ctx *ServiceContext) (Service, error) {
+		return &fakeServiceA{}, nil
+	}
+
+	if err := n.Register(ctor); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := n.Register(ctor); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	if _, err := n.buildServices(); err == nil {
+		t.Fatal("expected buildServices to fail for a duplicate service type")
+	}
+}
+
+func Test_Node_RegisterHandler_RejectsRootPath(t *testing.T) {
+	n, err := New(&Config{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if err := n.RegisterHandler("test", "/", http.NotFoundHandler()); err == nil {
+		t.Fatal("expected RegisterHandler to reject the reserved root path")
+	}
+}
+
+func Test_Node_RegisterHandler_RejectsDuplicatePath(t *testing.T) {
+	n, err := New(&Config{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if err := n.RegisterHandler("first", "/metrics", http.NotFoundHandler()); err != nil {
+		t.Fatalf("RegisterHandler returned error: %v", err)
+	}
+
+	if err := n.RegisterHandler("second", "/metrics", http.NotFoundHandler()); err == nil {
+		t.Fatal("expected RegisterHandler to reject a path that is already registered")
+	}
+}
@@ -0,0 +1,51 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package node
+
+import (
+	"io"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsMessageConn adapts a *websocket.Conn to the io.ReadWriteCloser that rpc.NewJsonCodec
+// expects, so each JSON-RPC request/response round-trips as a single websocket message.
+type wsMessageConn struct {
+	*websocket.Conn
+
+	reader io.Reader
+}
+
+// Read implements io.Reader by pulling bytes from the current (or next) websocket message.
+func (c *wsMessageConn) Read(p []byte) (int, error) {
+	if c.reader == nil {
+		_, r, err := c.Conn.NextReader()
+		if err != nil {
+			return 0, err
+		}
+		c.reader = r
+	}
+
+	n, err := c.reader.Read(p)
+	if err == io.EOF {
+		c.reader = nil
+		if n == 0 {
+			return c.Read(p)
+		}
+		err = nil
+	}
+
+	return n, err
+}
+
+// Write implements io.Writer by sending p as a single text websocket message.
+func (c *wsMessageConn) Write(p []byte) (int, error) {
+	if err := c.Conn.WriteMessage(websocket.TextMessage, p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,24 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import "github.com/seeleteam/go-seele/rpc"
+
+// APIs returns the RPC APIs the seele service wants to expose: "debug", for low-level chain
+// and tx-pool inspection, and "txpool", for the structured pending/queued introspection
+// implemented by PublicTxPoolAPI.
+func (s *SeeleService) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "debug",
+			Service:   NewPublicDebugAPI(s),
+		},
+		{
+			Namespace: "txpool",
+			Service:   NewPublicTxPoolAPI(s),
+		},
+	}
+}
@@ -0,0 +1,28 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import "testing"
+
+func Test_SeeleService_APIs_IncludesDebugAndTxPoolNamespaces(t *testing.T) {
+	s := new(SeeleService)
+
+	apis := s.APIs()
+	if len(apis) < 2 {
+		t.Fatalf("got %d APIs, want at least 2", len(apis))
+	}
+
+	namespaces := make(map[string]bool, len(apis))
+	for _, api := range apis {
+		namespaces[api.Namespace] = true
+	}
+
+	for _, want := range []string{"debug", "txpool"} {
+		if !namespaces[want] {
+			t.Fatalf("APIs() = %v, want a %q namespace", apis, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+// PublicTxPoolAPI provides an API, under the "txpool" namespace, to introspect the pending
+// and queued transactions held by the pool. It mirrors the shape of the geth txpool_*
+// methods so existing block-explorer and tooling integrations can point at a Seele node.
+type PublicTxPoolAPI struct {
+	s *SeeleService
+}
+
+// NewPublicTxPoolAPI creates a new PublicTxPoolAPI object for rpc service.
+func NewPublicTxPoolAPI(s *SeeleService) *PublicTxPoolAPI {
+	return &PublicTxPoolAPI{s}
+}
+
+// Content returns the pending and queued transactions in the pool, grouped by sender
+// address and then by nonce.
+func (api *PublicTxPoolAPI) Content(input interface{}, result *map[string]map[string]map[string]interface{}) error {
+	pending, queued := api.s.TxPool().GetPendingAndQueued()
+
+	*result = map[string]map[string]map[string]interface{}{
+		"pending": dumpTxBucket(pending),
+		"queued":  dumpTxBucket(queued),
+	}
+
+	return nil
+}
+
+// Inspect returns a compact, human-readable summary of the pending and queued transactions
+// in the pool, grouped by sender address and then by nonce.
+func (api *PublicTxPoolAPI) Inspect(input interface{}, result *map[string]map[string]map[string]string) error {
+	pending, queued := api.s.TxPool().GetPendingAndQueued()
+
+	*result = map[string]map[string]map[string]string{
+		"pending": inspectTxBucket(pending),
+		"queued":  inspectTxBucket(queued),
+	}
+
+	return nil
+}
+
+// TxPoolStatus reports the number of pending and queued transactions in the pool.
+type TxPoolStatus struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+}
+
+// Status returns the number of pending and queued transactions in the pool.
+func (api *PublicTxPoolAPI) Status(input interface{}, result *TxPoolStatus) error {
+	pending, queued := api.s.TxPool().GetPendingAndQueued()
+
+	result.Pending = countTxs(pending)
+	result.Queued = countTxs(queued)
+
+	return nil
+}
+
+// dumpTxBucket renders a pending/queued bucket as nested maps of hex address to nonce
+// string to the transaction's full RPC representation.
+func dumpTxBucket(bucket map[common.Address]map[uint64]*types.Transaction) map[string]map[string]interface{} {
+	dump := make(map[string]map[string]interface{}, len(bucket))
+
+	for address, txs := range bucket {
+		byNonce := make(map[string]interface{}, len(txs))
+		for nonce, tx := range txs {
+			byNonce[strconv.FormatUint(nonce, 10)] = rpcOutputTx(tx)
+		}
+
+		dump[address.ToHex()] = byNonce
+	}
+
+	return dump
+}
+
+// inspectTxBucket renders a pending/queued bucket as nested maps of hex address to nonce
+// string to a compact "to: value wei + gas × gasPrice" summary.
+func inspectTxBucket(bucket map[common.Address]map[uint64]*types.Transaction) map[string]map[string]string {
+	inspect := make(map[string]map[string]string, len(bucket))
+
+	for address, txs := range bucket {
+		byNonce := make(map[string]string, len(txs))
+		for nonce, tx := range txs {
+			byNonce[strconv.FormatUint(nonce, 10)] = fmt.Sprintf("%s: %v wei + %v gas × %v",
+				tx.Data.To.ToHex(), tx.Data.Amount, tx.Data.GasLimit, tx.Data.GasPrice)
+		}
+
+		inspect[address.ToHex()] = byNonce
+	}
+
+	return inspect
+}
+
+// countTxs sums the number of transactions across every address in a pending/queued bucket.
+func countTxs(bucket map[common.Address]map[uint64]*types.Transaction) int {
+	count := 0
+	for _, txs := range bucket {
+		count += len(txs)
+	}
+
+	return count
+}
@@ -0,0 +1,24 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+func Test_CountTxs(t *testing.T) {
+	bucket := map[common.Address]map[uint64]*types.Transaction{
+		common.BytesToAddress([]byte{1}): {0: nil, 1: nil},
+		common.BytesToAddress([]byte{2}): {0: nil},
+	}
+
+	if got := countTxs(bucket); got != 3 {
+		t.Fatalf("got %d, want 3", got)
+	}
+}
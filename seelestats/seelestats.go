@@ -0,0 +1,352 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package seelestats implements the network stats reporting service, which streams node
+// and chain status to a central dashboard over a websocket connection. It follows the same
+// "hello then periodic reports" protocol as the ethstats client used by other Ethereum-style
+// clients.
+package seelestats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/seeleteam/go-seele/node"
+	"github.com/seeleteam/go-seele/p2p"
+	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seele"
+)
+
+const (
+	historyBlocks = 50
+
+	statsReportInterval = 10 * time.Second
+
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// Service streams node status to a stats dashboard over a websocket connection.
+type Service struct {
+	name    string
+	secret  string
+	host    string
+	network uint64
+
+	seele *seele.SeeleService
+	p2p   *p2p.Server
+
+	log *log.SeeleLog
+
+	cancel context.CancelFunc
+}
+
+// New returns a node.ServiceConstructor for the stats reporter, configured with a URL of the
+// form "nodename:secret@host".
+func New(url string) node.ServiceConstructor {
+	return func(ctx *node.ServiceContext) (node.Service, error) {
+		name, secret, host, err := parseURL(url)
+		if err != nil {
+			return nil, err
+		}
+
+		var s *seele.SeeleService
+		if err := ctx.Service(&s); err != nil {
+			return nil, fmt.Errorf("seelestats: %v", err)
+		}
+
+		return &Service{
+			name:    name,
+			secret:  secret,
+			host:    host,
+			network: ctx.Config().NetworkID,
+			seele:   s,
+			log:     log.GetLogger("seelestats", true),
+		}, nil
+	}
+}
+
+// parseURL splits a "nodename:secret@host" stats URL into its parts.
+func parseURL(url string) (name, secret, host string, err error) {
+	parts := strings.SplitN(url, "@", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("invalid stats url %q, want nodename:secret@host", url)
+	}
+	host = parts[1]
+
+	login := strings.SplitN(parts[0], ":", 2)
+	if len(login) != 2 {
+		return "", "", "", fmt.Errorf("invalid stats url %q, want nodename:secret@host", url)
+	}
+
+	return login[0], login[1], host, nil
+}
+
+// Protocols implements node.Service. The stats reporter does not speak any p2p protocol.
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+// APIs implements node.Service. The stats reporter does not expose any RPC API.
+func (s *Service) APIs() []rpc.API { return nil }
+
+// Start implements node.Service, recording the local p2p server and launching the reporting
+// loop in the background.
+func (s *Service) Start(server *p2p.Server) error {
+	s.p2p = server
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go s.loop(ctx)
+
+	return nil
+}
+
+// Stop implements node.Service, terminating the reporting loop.
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	return nil
+}
+
+// loop dials the stats server, authenticates, and streams reports until ctx is cancelled,
+// reconnecting with exponential backoff whenever the connection drops.
+func (s *Service) loop(ctx context.Context) {
+	backoff := minBackoff
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		conn, err := s.dial()
+		if err != nil {
+			s.log.Warn("seelestats: dial failed", "err", err)
+			if !sleep(ctx, backoff) {
+				return
+			}
+			backoff = nextBackoff(backoff)
+			continue
+		}
+
+		connectedAt := time.Now()
+		if err := s.report(ctx, conn); err != nil {
+			s.log.Warn("seelestats: connection lost", "err", err)
+		}
+		conn.Close()
+
+		// A connection that stayed up longer than the longest backoff resets it, so a stable
+		// link that drops once redials quickly while a flapping one keeps backing off.
+		if time.Since(connectedAt) > maxBackoff {
+			backoff = minBackoff
+		}
+		if !sleep(ctx, backoff) {
+			return
+		}
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// dial opens the websocket connection and sends the hello handshake.
+func (s *Service) dial() (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/api", s.host), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hello := map[string]interface{}{
+		"emit": []interface{}{"hello", map[string]interface{}{
+			"id":     s.name,
+			"secret": s.secret,
+			"info": map[string]interface{}{
+				"name":     s.name,
+				"node":     "seele",
+				"protocol": "seele/1",
+				"network":  fmt.Sprintf("%d", s.network),
+				"client":   "0.1.0",
+			},
+		}},
+	}
+
+	if err := conn.WriteJSON(hello); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// report subscribes to the chain head event and the txpool, and pushes reports as they occur,
+// alongside a periodic stats heartbeat, until the connection fails or ctx is cancelled.
+func (s *Service) report(ctx context.Context, conn *websocket.Conn) error {
+	chainHeadCh := make(chan core.ChainHeadEvent, 16)
+	chainHeadSub := s.seele.BlockChain().SubscribeChainHeadEvent(chainHeadCh)
+	defer chainHeadSub.Unsubscribe()
+
+	txPoolCh := make(chan struct{}, 16)
+	txPoolSub := s.seele.TxPool().SubscribeTxPreEvent(txPoolCh)
+	defer txPoolSub.Unsubscribe()
+
+	if err := s.reportHistory(conn, nil); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(statsReportInterval)
+	defer ticker.Stop()
+
+	// gorilla/websocket requires the application to keep reading so control frames (ping,
+	// pong, close) get processed; without it a server-initiated disconnect would only be
+	// noticed on the next write, which can be long delayed.
+	readErrCh := make(chan error, 1)
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				readErrCh <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event := <-chainHeadCh:
+			if err := s.reportBlock(conn, event.Block); err != nil {
+				return err
+			}
+			if err := s.reportPending(conn); err != nil {
+				return err
+			}
+
+		case <-txPoolCh:
+			if err := s.reportPending(conn); err != nil {
+				return err
+			}
+
+		case <-ticker.C:
+			if err := s.reportStats(conn); err != nil {
+				return err
+			}
+
+		case err := <-chainHeadSub.Err():
+			return err
+
+		case err := <-txPoolSub.Err():
+			return err
+
+		case err := <-readErrCh:
+			return err
+		}
+	}
+}
+
+// reportBlock emits a "block" report for the given block.
+func (s *Service) reportBlock(conn *websocket.Conn, block *types.Block) error {
+	return s.emit(conn, "block", map[string]interface{}{"block": blockStats(block)})
+}
+
+// reportPending emits a "pending" report with the current number of processable transactions.
+func (s *Service) reportPending(conn *websocket.Conn) error {
+	return s.emit(conn, "pending", map[string]interface{}{
+		"pending": s.seele.TxPool().GetProcessableTransactionsCount(),
+	})
+}
+
+// reportHistory emits a "history" report with the last historyBlocks headers, or the blocks
+// listed explicitly if provided.
+func (s *Service) reportHistory(conn *websocket.Conn, blocks []*types.Block) error {
+	if blocks == nil {
+		chain := s.seele.BlockChain()
+		head := chain.CurrentBlock()
+
+		for i := uint64(0); i < historyBlocks && head != nil; i++ {
+			blocks = append([]*types.Block{head}, blocks...)
+			head = chain.GetBlockByHash(head.Header.PreviousBlockHash)
+		}
+	}
+
+	history := make([]map[string]interface{}, len(blocks))
+	for i, block := range blocks {
+		history[i] = blockStats(block)
+	}
+
+	return s.emit(conn, "history", map[string]interface{}{"history": history})
+}
+
+// reportStats emits a "stats" report summarizing peer count, sync status, and miner state.
+func (s *Service) reportStats(conn *websocket.Conn) error {
+	miner := s.seele.Miner()
+
+	return s.emit(conn, "stats", map[string]interface{}{
+		"active":   true,
+		"peers":    s.p2p.PeerCount(),
+		"mining":   miner.IsMining(),
+		"hashrate": miner.Hashrate(),
+		"syncing":  s.seele.IsSyncing(),
+		"gasPrice": big.NewInt(0),
+	})
+}
+
+// emit sends a single stats-protocol frame of the given type.
+func (s *Service) emit(conn *websocket.Conn, kind string, data map[string]interface{}) error {
+	data["id"] = s.name
+
+	frame := map[string]interface{}{
+		"emit": []interface{}{kind, data},
+	}
+
+	payload, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// blockStats extracts the subset of a block's header used in stats reports.
+func blockStats(block *types.Block) map[string]interface{} {
+	if block == nil {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"number":     block.Header.Height,
+		"hash":       block.HeaderHash.ToHex(),
+		"parentHash": block.Header.PreviousBlockHash.ToHex(),
+		"timestamp":  block.Header.CreateTimestamp.Uint64(),
+		"txCount":    len(block.Transactions),
+	}
+}
+
+// sleep waits for d, or returns false early if ctx is cancelled.
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// nextBackoff doubles d, capped at maxBackoff.
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > maxBackoff {
+		return maxBackoff
+	}
+
+	return d
+}
@@ -0,0 +1,26 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seelestats
+
+import "testing"
+
+func Test_ParseURL(t *testing.T) {
+	name, secret, host, err := parseURL("node1:supersecret@stats.seele.io")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "node1" || secret != "supersecret" || host != "stats.seele.io" {
+		t.Fatalf("got (%q, %q, %q), want (node1, supersecret, stats.seele.io)", name, secret, host)
+	}
+}
+
+func Test_ParseURL_Invalid(t *testing.T) {
+	for _, url := range []string{"", "node1@host", "node1:secret"} {
+		if _, _, _, err := parseURL(url); err == nil {
+			t.Fatalf("expected an error for invalid url %q", url)
+		}
+	}
+}